@@ -0,0 +1,47 @@
+package quadtree
+
+// IntersectsCircle reports whether r intersects the circle centered at
+// (cx, cy) with the given radius, using the closest-point-on-rect test. The
+// comparison is done in float64, like rectDistSq, because dx*dx+dy*dy and
+// radius*radius computed in T (int32 for QuadTree) overflows for world sizes well within
+// this package's stated game/collision use cases.
+func (r Rect[T]) IntersectsCircle(cx T, cy T, radius T) bool {
+	closestX := cx
+	if closestX < r.X {
+		closestX = r.X
+	} else if closestX > r.X+r.Width {
+		closestX = r.X + r.Width
+	}
+
+	closestY := cy
+	if closestY < r.Y {
+		closestY = r.Y
+	} else if closestY > r.Y+r.Height {
+		closestY = r.Y + r.Height
+	}
+
+	dx := float64(cx - closestX)
+	dy := float64(cy - closestY)
+	return dx*dx+dy*dy <= float64(radius)*float64(radius)
+}
+
+// RetrieveCircle prunes with the circle's bounding square and then invokes fn
+// for every object whose bounds actually intersect the circle.
+func (t *tree[T]) RetrieveCircle(cx T, cy T, radius T, fn func(obj object[T])) {
+	t.root.retrieve(circleBounds(cx, cy, radius), func(obj object[T]) {
+		if obj.Bounds.IntersectsCircle(cx, cy, radius) {
+			fn(obj)
+		}
+	})
+}
+
+// CheckCircle prunes with the circle's bounding square and returns true as
+// soon as fn returns true for an object whose bounds intersect the circle.
+func (t *tree[T]) CheckCircle(cx T, cy T, radius T, fn func(obj object[T]) bool) bool {
+	return t.root.check(circleBounds(cx, cy, radius), func(obj object[T]) bool {
+		if !obj.Bounds.IntersectsCircle(cx, cy, radius) {
+			return false
+		}
+		return fn(obj)
+	})
+}
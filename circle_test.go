@@ -0,0 +1,61 @@
+package quadtree
+
+import "testing"
+
+func TestRetrieveCircle(t *testing.T) {
+	qt := NewQuadTree(100, 100, 4, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), nil)) // inside the circle
+	qt.Insert(NewObject(2, MakeRect(14, 14, 1, 1), nil)) // inside the bounding square, outside the circle
+	qt.Insert(NewObject(3, MakeRect(60, 60, 2, 2), nil)) // outside the bounding square entirely
+
+	var got []int64
+	qt.RetrieveCircle(10, 10, 5, func(obj Object) {
+		got = append(got, obj.Id)
+	})
+
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("RetrieveCircle = %v, want [1]", got)
+	}
+}
+
+func TestCheckCircle(t *testing.T) {
+	qt := NewQuadTree(100, 100, 4, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), nil))
+	qt.Insert(NewObject(2, MakeRect(90, 90, 2, 2), nil))
+
+	if qt.CheckCircle(90, 10, 5, func(obj Object) bool { return true }) {
+		t.Fatal("CheckCircle found a hit where none should intersect")
+	}
+	if !qt.CheckCircle(10, 10, 5, func(obj Object) bool { return true }) {
+		t.Fatal("CheckCircle missed object 1, which is inside the circle")
+	}
+}
+
+func TestIntersectsCircle(t *testing.T) {
+	rect := MakeRect(0, 0, 10, 10)
+	if !rect.IntersectsCircle(0, 0, 1) {
+		t.Fatal("circle centered inside the rect should intersect")
+	}
+	if rect.IntersectsCircle(50, 50, 5) {
+		t.Fatal("distant circle should not intersect")
+	}
+	// Closest point on the rect to (20, 5) is (10, 5), distance 10.
+	if rect.IntersectsCircle(20, 5, 9) {
+		t.Fatal("circle of radius 9 should fall short of the rect")
+	}
+	if !rect.IntersectsCircle(20, 5, 10) {
+		t.Fatal("circle of radius 10 should just reach the rect")
+	}
+}
+
+// TestIntersectsCircleNoOverflow guards against the int32 overflow that used
+// to corrupt IntersectsCircle at world sizes this package treats as
+// realistic: the true closest-point distance from (0,0) to this rect is
+// ~141421, well outside a radius of 90000, but dx*dx+dy*dy computed in int32
+// wrapped and reported a false positive.
+func TestIntersectsCircleNoOverflow(t *testing.T) {
+	rect := MakeRect(100000, 100000, 10, 10)
+	if rect.IntersectsCircle(0, 0, 90000) {
+		t.Fatal("IntersectsCircle overflowed: reported an intersection far outside the radius")
+	}
+}
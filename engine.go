@@ -0,0 +1,395 @@
+package quadtree
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Num is the coordinate type shared by QuadTree's int32-based Rectangle and
+// QuadTreeF's float64-based RectangleF. QuadTree and QuadTreeF are both thin
+// aliases over the generic tree[T] engine in this file, so the two variants
+// share one implementation and can't drift apart.
+type Num interface {
+	~int32 | ~float64
+}
+
+// Rect is the generic rectangle behind Rectangle (int32) and RectangleF
+// (float64).
+//
+//	(0,0)
+//	+--------------------------->X
+//	|  (x, y)
+//	|    +-----------+
+//	|    |           | height
+//	|    +-----------+
+//	|         width
+//	|
+//	V Y
+type Rect[T Num] struct {
+	X      T // left top
+	Y      T // left top
+	Width  T
+	Height T
+}
+
+func makeRect[T Num](x T, y T, width T, height T) Rect[T] {
+	return Rect[T]{X: x, Y: y, Width: width, Height: height}
+}
+
+func (r Rect[T]) Contain(other Rect[T]) bool {
+	return other.X >= r.X &&
+		other.Y >= r.Y &&
+		other.X+other.Width <= r.X+r.Width &&
+		other.Y+other.Height <= r.Y+r.Height
+}
+
+// ToCircle returns the circle inscribed in r, or (-1, -1, -1) if r isn't
+// square.
+func (r Rect[T]) ToCircle() (T, T, T) {
+	if r.Width != r.Height {
+		return -1, -1, -1
+	}
+	half := r.Width / 2
+	return r.X + half, r.Y + half, half
+}
+
+func circleBounds[T Num](cx T, cy T, radius T) Rect[T] {
+	return Rect[T]{X: cx - radius, Y: cy - radius, Width: radius * 2, Height: radius * 2}
+}
+
+// object is the generic type behind Object (int32) and ObjectF (float64).
+type object[T Num] struct {
+	Id     int64
+	Bounds Rect[T]
+	Data   interface{}
+}
+
+type node[T Num] struct {
+	objects     map[int64]object[T]
+	children    []*node[T]
+	parent      *node[T]
+	bounds      Rect[T]
+	maxLevels   int
+	maxObjects  int
+	level       int
+	looseness   float64
+	minCellSize T
+	strategy    SplitStrategy
+	pool        *sync.Pool
+}
+
+func newNode[T Num](x T, y T, width T, height T, level int, maxObjects int, maxLevels int, opts TreeOptions[T], pool *sync.Pool) *node[T] {
+	n := pool.Get().(*node[T])
+	n.bounds = Rect[T]{x, y, width, height}
+	n.children = nil
+	n.parent = nil
+	n.maxLevels = maxLevels
+	n.maxObjects = maxObjects
+	n.level = level
+	n.looseness = opts.Looseness
+	n.minCellSize = opts.MinCellSize
+	n.strategy = opts.Strategy
+	n.pool = pool
+	if n.objects == nil {
+		n.objects = make(map[int64]object[T])
+	}
+	return n
+}
+
+// canSplit reports whether n is allowed to subdivide once more: its
+// children must not fall below the tree's configured MinCellSize.
+func (n *node[T]) canSplit() bool {
+	if n.minCellSize <= 0 {
+		return true
+	}
+	return n.bounds.Width/2 >= n.minCellSize && n.bounds.Height/2 >= n.minCellSize
+}
+
+// release clears n's objects and returns n, and every descendant, to n's pool.
+func (n *node[T]) release() {
+	for _, child := range n.children {
+		child.release()
+	}
+	n.children = nil
+	for id := range n.objects {
+		delete(n.objects, id)
+	}
+	n.pool.Put(n)
+}
+
+func (n *node[T]) split() {
+	nextLevel := n.level + 1
+	subWidth := n.bounds.Width / 2
+	subHeight := n.bounds.Height / 2
+	x := n.bounds.X
+	y := n.bounds.Y
+	n.children = make([]*node[T], 4)
+	opts := TreeOptions[T]{Looseness: n.looseness, MinCellSize: n.minCellSize, Strategy: n.strategy}
+
+	// top right node
+	n.children[0] = newNode(x+subWidth, y, subWidth, subHeight, nextLevel, n.maxObjects, n.maxLevels, opts, n.pool)
+
+	// top left node
+	n.children[1] = newNode(x, y, subWidth, subHeight, nextLevel, n.maxObjects, n.maxLevels, opts, n.pool)
+
+	// bottom left node
+	n.children[2] = newNode(x, y+subHeight, subWidth, subHeight, nextLevel, n.maxObjects, n.maxLevels, opts, n.pool)
+
+	// bottom right node
+	n.children[3] = newNode(x+subWidth, y+subHeight, subWidth, subHeight, nextLevel, n.maxObjects, n.maxLevels, opts, n.pool)
+
+	for _, child := range n.children {
+		child.parent = n
+	}
+}
+
+// getIndex determines which quadrant rect belongs to (0-3), or -1 if it
+// cannot be routed into a single child and stays part of the parent node.
+// In loose mode (see TreeOptions) each quadrant's test region is inflated
+// around its center, so straddling rects are more likely to descend instead
+// of piling up at ancestors.
+func (n *node[T]) getIndex(rect Rect[T]) int {
+	if n.strategy == SplitLoose {
+		return n.getLooseIndex(rect)
+	}
+
+	index := -1
+
+	midX := n.bounds.X + (n.bounds.Width / 2)
+	midY := n.bounds.Y + (n.bounds.Height / 2)
+
+	topQuadrant := (rect.Y < midY) && (rect.Y+rect.Height < midY)
+	bottomQuadrant := rect.Y > midY
+
+	if (rect.X < midX) && (rect.X+rect.Width < midX) {
+		if topQuadrant {
+			index = 1
+		} else if bottomQuadrant {
+			index = 2
+		}
+	} else if rect.X > midX {
+		if topQuadrant {
+			index = 0
+		} else if bottomQuadrant {
+			index = 3
+		}
+	}
+	return index
+}
+
+func (n *node[T]) insert(obj object[T], index map[int64]*node[T]) {
+	if len(n.children) > 0 {
+		if idx := n.getIndex(obj.Bounds); idx != -1 {
+			n.children[idx].insert(obj, index)
+			return
+		}
+	}
+
+	n.objects[obj.Id] = obj
+	index[obj.Id] = n
+	if len(n.objects) > n.maxObjects && n.level < n.maxLevels && n.canSplit() {
+		if len(n.children) == 0 {
+			n.split()
+		}
+		for _, obj := range n.objects {
+			if idx := n.getIndex(obj.Bounds); idx != -1 {
+				n.children[idx].insert(obj, index)
+				delete(n.objects, obj.Id)
+			}
+		}
+	}
+}
+
+func (n *node[T]) foreach(fn func(obj object[T])) {
+	for _, child := range n.children {
+		child.foreach(fn)
+	}
+	for _, obj := range n.objects {
+		fn(obj)
+	}
+}
+
+func (n *node[T]) retrieve(bounds Rect[T], fn func(obj object[T])) {
+	if len(n.children) > 0 {
+		if index := n.getIndex(bounds); index != -1 {
+			n.children[index].retrieve(bounds, fn)
+		} else {
+			for _, child := range n.children {
+				child.retrieve(bounds, fn)
+			}
+		}
+	}
+	for _, obj := range n.objects {
+		fn(obj)
+	}
+}
+
+func (n *node[T]) check(bounds Rect[T], fn func(obj object[T]) bool) bool {
+	for _, obj := range n.objects {
+		if fn(obj) {
+			return true
+		}
+	}
+	if len(n.children) > 0 {
+		if index := n.getIndex(bounds); index != -1 {
+			return n.children[index].check(bounds, fn)
+		} else {
+			for _, child := range n.children {
+				if child.check(bounds, fn) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (n *node[T]) show(levels [][]*node[T]) {
+	for _, child := range n.children {
+		child.show(levels)
+	}
+	levels[n.level] = append(levels[n.level], n)
+}
+
+// tree is the generic engine behind QuadTree (T=int32) and QuadTreeF
+// (T=float64).
+type tree[T Num] struct {
+	width      T
+	height     T
+	maxObjects int
+	maxLevels  int
+	options    TreeOptions[T]
+	root       *node[T]
+	index      map[int64]*node[T]
+	nodePool   sync.Pool
+}
+
+func newTree[T Num](width T, height T, maxObjects int, maxLevels int, opts ...TreeOptions[T]) *tree[T] {
+	if maxObjects <= 0 {
+		maxObjects = DEFAULT_MAX_OBJECTS
+	}
+	if maxLevels <= 0 {
+		maxLevels = DEFAULT_MAX_LEVELS
+	}
+	options := defaultTreeOptions[T]()
+	if len(opts) > 0 {
+		options = opts[0]
+		if options.Looseness < 1.0 {
+			options.Looseness = 1.0
+		}
+	}
+	t := &tree[T]{
+		width:      width,
+		height:     height,
+		maxObjects: maxObjects,
+		maxLevels:  maxLevels,
+		options:    options,
+		index:      make(map[int64]*node[T]),
+	}
+	t.nodePool.New = func() interface{} { return &node[T]{} }
+	t.root = newNode[T](0, 0, width, height, 1, maxObjects, maxLevels, options, &t.nodePool)
+	return t
+}
+
+// Clear empties the tree, returning every node to its pool, and resets it to
+// a fresh root covering the tree's original bounds.
+func (t *tree[T]) Clear() {
+	t.root.release()
+	t.root = newNode[T](0, 0, t.width, t.height, 1, t.maxObjects, t.maxLevels, t.options, &t.nodePool)
+	t.index = make(map[int64]*node[T])
+}
+
+// Rebuild clears the tree and bulk-inserts objs, which is typically cheaper
+// than incrementally removing and re-inserting moved objects every tick.
+func (t *tree[T]) Rebuild(objs []object[T]) {
+	t.Clear()
+	for _, obj := range objs {
+		t.Insert(obj)
+	}
+}
+
+func (t *tree[T]) Insert(obj object[T]) {
+	t.root.insert(obj, t.index)
+}
+
+func (t *tree[T]) Retrieve(bound Rect[T], fn func(obj object[T])) {
+	t.root.retrieve(bound, fn)
+}
+
+func (t *tree[T]) Foreach(fn func(obj object[T])) {
+	t.root.foreach(fn)
+}
+
+func (t *tree[T]) Check(bound Rect[T], fn func(obj object[T]) bool) bool {
+	return t.root.check(bound, fn)
+}
+
+// Remove deletes the object with the given id from the tree. bounds is kept
+// for API compatibility but is no longer consulted: the id index makes
+// removal O(1) without a tree walk.
+func (t *tree[T]) Remove(bounds Rect[T], id int64) {
+	node, ok := t.index[id]
+	if !ok {
+		return
+	}
+	delete(node.objects, id)
+	delete(t.index, id)
+}
+
+// Get returns the object with the given id, if present.
+func (t *tree[T]) Get(id int64) (object[T], bool) {
+	node, ok := t.index[id]
+	if !ok {
+		return object[T]{}, false
+	}
+	obj, ok := node.objects[id]
+	return obj, ok
+}
+
+// Update relocates the object with the given id to newBounds, replacing its
+// data. If newBounds still belongs at the node currently holding the object
+// — it's still contained by the node's bounds, and getIndex says it doesn't
+// route into one of the node's children — the entry is mutated in place.
+// Otherwise the object is removed and re-inserted starting from the lowest
+// ancestor whose bounds still contain newBounds, avoiding a full
+// root-to-leaf redescent. It reports whether the object was found.
+func (t *tree[T]) Update(id int64, newBounds Rect[T], data interface{}) bool {
+	n, ok := t.index[id]
+	if !ok {
+		return false
+	}
+	obj, ok := n.objects[id]
+	if !ok {
+		return false
+	}
+
+	obj.Bounds = newBounds
+	obj.Data = data
+
+	fitsHere := n.bounds.Contain(newBounds) &&
+		(len(n.children) == 0 || n.getIndex(newBounds) == -1)
+	if fitsHere {
+		n.objects[id] = obj
+		return true
+	}
+
+	delete(n.objects, id)
+	delete(t.index, id)
+
+	ancestor := n
+	for ancestor.parent != nil && !ancestor.bounds.Contain(newBounds) {
+		ancestor = ancestor.parent
+	}
+	ancestor.insert(obj, t.index)
+	return true
+}
+
+func (t *tree[T]) Show() {
+	levels := make([][]*node[T], t.maxLevels+1)
+	t.root.show(levels)
+	for _, nodes := range levels {
+		for _, n := range nodes {
+			fmt.Printf("level: %d, bound: %v, objects: %d\n", n.level, n.bounds, len(n.objects))
+		}
+	}
+}
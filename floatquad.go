@@ -0,0 +1,46 @@
+package quadtree
+
+// RectangleF is the float64, world-space rectangle used by QuadTreeF, for
+// callers working in continuous coordinate space instead of int32 pixels.
+// It's an alias for the generic Rect so QuadTreeF shares its implementation
+// with QuadTree (see engine.go) instead of carrying a hand-maintained copy.
+type RectangleF = Rect[float64]
+
+func MakeRectF(x float64, y float64, width float64, height float64) RectangleF {
+	return makeRect(x, y, width, height)
+}
+
+func CircleBoundsF(cx float64, cy float64, radius float64) RectangleF {
+	return circleBounds(cx, cy, radius)
+}
+
+// ObjectF is the float64 counterpart stored in QuadTreeF.
+type ObjectF = object[float64]
+
+func NewObjectF(id int64, bounds RectangleF, data interface{}) ObjectF {
+	return ObjectF{
+		Id:     id,
+		Bounds: bounds,
+		Data:   data,
+	}
+}
+
+// QuadTreeOptionsF configures loose-quadtree behavior for QuadTreeF. See
+// TreeOptions for field docs.
+type QuadTreeOptionsF = TreeOptions[float64]
+
+// QuadTreeF is the float64 counterpart to QuadTree, for callers that work in
+// floating-point coordinate space (world-space or geographic coordinates)
+// rather than int32 pixels. It's an alias for the same generic tree engine
+// that backs QuadTree, so Insert, Retrieve, Check, Remove, Get, Update,
+// Clear, Rebuild, loose splitting, the circle queries and the kNN queries
+// all share one implementation and can't silently drift between the two
+// variants.
+type QuadTreeF = tree[float64]
+
+// NewQuadTreeF creates a tree covering (0, 0, width, height). opts is
+// variadic so existing callers are unaffected; passing it configures loose
+// splitting (see QuadTreeOptionsF).
+func NewQuadTreeF(width float64, height float64, maxObjects int, maxLevels int, opts ...QuadTreeOptionsF) *QuadTreeF {
+	return newTree[float64](width, height, maxObjects, maxLevels, opts...)
+}
@@ -0,0 +1,68 @@
+package quadtree
+
+import "testing"
+
+// TestQuadTreeFParity exercises the features QuadTreeF used to lack
+// compared to QuadTree -- Update/Get, Clear/Rebuild, and loose splitting --
+// now that both share the generic tree engine in engine.go.
+func TestQuadTreeFParity(t *testing.T) {
+	qt := NewQuadTreeF(100, 100, 1, 4, QuadTreeOptionsF{Looseness: 2.0, Strategy: SplitLoose})
+	qt.Insert(NewObjectF(1, MakeRectF(10, 10, 2, 2), nil))
+	qt.Insert(NewObjectF(2, MakeRectF(20, 20, 2, 2), "b"))
+
+	if obj, ok := qt.Get(2); !ok || obj.Data != "b" {
+		t.Fatalf("Get(2) = %v, %v, want {Data: b}, true", obj, ok)
+	}
+
+	if !qt.Update(1, MakeRectF(50, 50, 2, 2), "moved") {
+		t.Fatal("Update reported object 1 as not found")
+	}
+	if obj, ok := qt.Get(1); !ok || obj.Bounds != MakeRectF(50, 50, 2, 2) || obj.Data != "moved" {
+		t.Fatalf("Get(1) after Update = %v, %v, want updated bounds/data", obj, ok)
+	}
+
+	qt.Rebuild([]ObjectF{NewObjectF(3, MakeRectF(5, 5, 2, 2), nil)})
+	if _, ok := qt.Get(1); ok {
+		t.Fatal("Rebuild left a stale object from before the call")
+	}
+	if _, ok := qt.Get(3); !ok {
+		t.Fatal("Rebuild dropped the new object")
+	}
+
+	qt.Clear()
+	count := 0
+	qt.Foreach(func(obj ObjectF) { count++ })
+	if count != 0 {
+		t.Fatalf("Foreach visited %d objects after Clear, want 0", count)
+	}
+}
+
+func TestQuadTreeFRetrieveAndCircle(t *testing.T) {
+	qt := NewQuadTreeF(100, 100, 4, 4)
+	qt.Insert(NewObjectF(1, MakeRectF(10, 10, 2, 2), nil))
+	qt.Insert(NewObjectF(2, MakeRectF(90, 90, 2, 2), nil))
+
+	var got []int64
+	qt.RetrieveCircle(10, 10, 5, func(obj ObjectF) {
+		got = append(got, obj.Id)
+	})
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("RetrieveCircle = %v, want [1]", got)
+	}
+
+	if qt.CheckCircle(90, 10, 5, func(obj ObjectF) bool { return true }) {
+		t.Fatal("CheckCircle found a hit where none should intersect")
+	}
+}
+
+func TestQuadTreeFKNearest(t *testing.T) {
+	qt := NewQuadTreeF(100, 100, 4, 4)
+	qt.Insert(NewObjectF(1, MakeRectF(10, 10, 2, 2), nil))
+	qt.Insert(NewObjectF(2, MakeRectF(20, 20, 2, 2), nil))
+	qt.Insert(NewObjectF(3, MakeRectF(90, 90, 2, 2), nil))
+
+	got := qt.KNearest(0, 0, 2, nil)
+	if len(got) != 2 || got[0].Id != 1 || got[1].Id != 2 {
+		t.Fatalf("KNearest(0,0,2) = %v, want [1, 2] in order", got)
+	}
+}
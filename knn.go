@@ -0,0 +1,119 @@
+package quadtree
+
+import "container/heap"
+
+// rectDistSq returns the squared distance from (x, y) to the closest point
+// of rect, or 0 if (x, y) lies inside rect. The result is a float64
+// regardless of T so that large int32 coordinates can't overflow the way a
+// fixed-width integer square would.
+func rectDistSq[T Num](rect Rect[T], x T, y T) float64 {
+	closestX := x
+	if closestX < rect.X {
+		closestX = rect.X
+	} else if closestX > rect.X+rect.Width {
+		closestX = rect.X + rect.Width
+	}
+
+	closestY := y
+	if closestY < rect.Y {
+		closestY = rect.Y
+	} else if closestY > rect.Y+rect.Height {
+		closestY = rect.Y + rect.Height
+	}
+
+	dx := float64(x - closestX)
+	dy := float64(y - closestY)
+	return dx*dx + dy*dy
+}
+
+// nodeDistSq returns the squared distance from (x, y) to n's search region.
+// Under SplitLoose, objects can be routed into n via its inflated (loose)
+// bounds rather than its tight bounds (see getLooseIndex), so the distance
+// must be measured against the same inflated region here too — otherwise
+// the tight-bounds distance can exceed the true distance to an object the
+// node actually holds, breaking the best-first search's lower-bound
+// invariant.
+func nodeDistSq[T Num](n *node[T], x T, y T) float64 {
+	bounds := n.bounds
+	if n.strategy == SplitLoose {
+		bounds = inflateAroundCenter(bounds, n.looseness)
+	}
+	return rectDistSq(bounds, x, y)
+}
+
+// knnItem is an entry in the best-first search heap: either an unexpanded
+// node or a candidate object, ordered by distance to the search point.
+type knnItem[T Num] struct {
+	distSq float64
+	id     int64
+	node   *node[T]
+	obj    *object[T]
+}
+
+type knnQueue[T Num] []*knnItem[T]
+
+func (q knnQueue[T]) Len() int { return len(q) }
+
+func (q knnQueue[T]) Less(i, j int) bool {
+	if q[i].distSq != q[j].distSq {
+		return q[i].distSq < q[j].distSq
+	}
+	return q[i].id < q[j].id
+}
+
+func (q knnQueue[T]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *knnQueue[T]) Push(x interface{}) { *q = append(*q, x.(*knnItem[T])) }
+
+func (q *knnQueue[T]) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// NearestFunc streams objects in order of increasing distance from (x, y)
+// using a best-first search over the tree, stopping as soon as fn returns
+// false.
+func (t *tree[T]) NearestFunc(x T, y T, fn func(obj object[T]) bool) {
+	pq := &knnQueue[T]{{distSq: nodeDistSq(t.root, x, y), node: t.root}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*knnItem[T])
+		if item.node != nil {
+			n := item.node
+			for _, child := range n.children {
+				heap.Push(pq, &knnItem[T]{distSq: nodeDistSq(child, x, y), node: child})
+			}
+			for id, obj := range n.objects {
+				obj := obj
+				heap.Push(pq, &knnItem[T]{distSq: rectDistSq(obj.Bounds, x, y), id: id, obj: &obj})
+			}
+			continue
+		}
+		if !fn(*item.obj) {
+			return
+		}
+	}
+}
+
+// KNearest returns up to k objects closest to (x, y), in increasing order of
+// distance. filter, if non-nil, is consulted for every candidate and objects
+// it rejects don't count towards k.
+func (t *tree[T]) KNearest(x T, y T, k int, filter func(obj object[T]) bool) []object[T] {
+	if k <= 0 {
+		return nil
+	}
+	result := make([]object[T], 0, k)
+	t.NearestFunc(x, y, func(obj object[T]) bool {
+		if filter != nil && !filter(obj) {
+			return true
+		}
+		result = append(result, obj)
+		return len(result) < k
+	})
+	return result
+}
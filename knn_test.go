@@ -0,0 +1,76 @@
+package quadtree
+
+import "testing"
+
+func TestKNearest(t *testing.T) {
+	qt := NewQuadTree(100, 100, 4, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), nil))
+	qt.Insert(NewObject(2, MakeRect(20, 20, 2, 2), nil))
+	qt.Insert(NewObject(3, MakeRect(90, 90, 2, 2), nil))
+
+	got := qt.KNearest(0, 0, 2, nil)
+	if len(got) != 2 || got[0].Id != 1 || got[1].Id != 2 {
+		t.Fatalf("KNearest(0,0,2) = %v, want [1, 2] in order", got)
+	}
+}
+
+func TestKNearestFilter(t *testing.T) {
+	qt := NewQuadTree(100, 100, 4, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), "skip"))
+	qt.Insert(NewObject(2, MakeRect(20, 20, 2, 2), "keep"))
+
+	got := qt.KNearest(0, 0, 1, func(obj Object) bool { return obj.Data != "skip" })
+	if len(got) != 1 || got[0].Id != 2 {
+		t.Fatalf("KNearest with filter = %v, want [2]", got)
+	}
+}
+
+// TestRectDistSqNoOverflow guards against the int32 overflow that used to
+// corrupt the best-first search's distance ordering for coordinates/spans
+// beyond about 46340 (where dx*dx would wrap negative in a fixed-width
+// int32 square).
+func TestRectDistSqNoOverflow(t *testing.T) {
+	rect := MakeRect(100000, 100000, 10, 10)
+	got := rectDistSq(rect, 0, 0)
+	want := float64(100000)*float64(100000) + float64(100000)*float64(100000)
+	if got != want {
+		t.Fatalf("rectDistSq overflowed: got %v, want %v", got, want)
+	}
+}
+
+// TestKNearestLooseBounds reproduces a bug where KNearest's best-first
+// search measured a node's lower-bound distance against its tight bounds
+// even under SplitLoose, where objects are actually routed using inflated
+// (loose) bounds (see getLooseIndex). A node holding an object that lies
+// outside the node's tight bounds but inside its loose region reported a
+// distance larger than the truth, so a closer decoy popped first and
+// KNearest returned it instead of the real nearest object.
+func TestKNearestLooseBounds(t *testing.T) {
+	leaf := func(bounds Rectangle) *node[int32] {
+		return &node[int32]{bounds: bounds, looseness: 2.0, strategy: SplitLoose, objects: map[int64]Object{}}
+	}
+
+	// nodeA's tight bounds report distSq=100 to (40,40), but it holds
+	// object 3 whose bounds lie just left of its left edge, so the real distance
+	// (50) is smaller than the tight bound claims.
+	nodeA := leaf(MakeRect(50, 25, 25, 25))
+	nodeA.objects[3] = NewObject(3, MakeRect(45, 45, 4, 4), nil)
+
+	root := leaf(MakeRect(0, 0, 100, 100))
+	// A decoy sitting directly on the root, real distSq=81 -- between the
+	// true answer (50) and nodeA's broken tight-bounds distance (100).
+	root.objects[2] = NewObject(2, MakeRect(49, 40, 2, 2), nil)
+	root.children = []*node[int32]{
+		nodeA,
+		leaf(MakeRect(75, 0, 25, 25)),
+		leaf(MakeRect(0, 50, 50, 50)),
+		leaf(MakeRect(50, 50, 50, 50)),
+	}
+
+	qt := &QuadTree{options: QuadTreeOptions{Looseness: 2.0, Strategy: SplitLoose}, root: root}
+
+	got := qt.KNearest(40, 40, 1, nil)
+	if len(got) != 1 || got[0].Id != 3 {
+		t.Fatalf("KNearest(40,40,1) = %v, want [3] (distSq=50), not the distSq=81 decoy", got)
+	}
+}
@@ -0,0 +1,89 @@
+package quadtree
+
+// SplitStrategy selects how a node decides which child a rect belongs to
+// when it subdivides.
+type SplitStrategy int
+
+const (
+	// SplitStrict routes a rect into a child only if it fits entirely
+	// within that child's exact half-extent quadrant (the original
+	// behavior). Rects straddling a split line stay at the parent.
+	SplitStrict SplitStrategy = iota
+
+	// SplitLoose routes a rect into a child whose bounds, inflated by
+	// Looseness around its center, contain the rect. This keeps
+	// long/thin or boundary-straddling objects from piling up at
+	// ancestors, at the cost of children overlapping during descent.
+	SplitLoose
+)
+
+// TreeOptions configures loose-quadtree behavior, generic over the same
+// coordinate type T as the tree it's passed to. QuadTreeOptions and
+// QuadTreeOptionsF are its int32 and float64 instantiations. The zero value
+// matches NewQuadTree/NewQuadTreeF's default, since a Looseness below 1.0 is
+// raised to 1.0 when the tree is created.
+type TreeOptions[T Num] struct {
+	// Looseness is the factor (>= 1.0) by which each child's test region
+	// is inflated around its center before routing a rect into it. At 1.0
+	// under SplitLoose the test regions are the children's exact bounds,
+	// so, unlike SplitStrict, a rect that touches a split line without
+	// crossing it is routed into the child containing it. Typical values
+	// are 1.5-2.0.
+	Looseness float64
+
+	// MinCellSize stops subdivision once a child's width or height would
+	// drop below it, independent of MaxLevels. Zero disables the limit.
+	MinCellSize T
+
+	// Strategy selects strict or loose child routing.
+	Strategy SplitStrategy
+}
+
+func defaultTreeOptions[T Num]() TreeOptions[T] {
+	return TreeOptions[T]{
+		Looseness: 1.0,
+		Strategy:  SplitStrict,
+	}
+}
+
+// inflateAroundCenter grows rect by factor k around its own center.
+func inflateAroundCenter[T Num](rect Rect[T], k float64) Rect[T] {
+	if k <= 1.0 {
+		return rect
+	}
+	cx := rect.X + rect.Width/2
+	cy := rect.Y + rect.Height/2
+	width := T(float64(rect.Width) * k)
+	height := T(float64(rect.Height) * k)
+	return Rect[T]{
+		X:      cx - width/2,
+		Y:      cy - height/2,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// getLooseIndex is the SplitLoose counterpart to getIndex: it tests rect
+// against each quadrant's loose (inflated) region instead of the exact
+// half-extent split, returning the first quadrant whose loose region
+// contains it, or -1 if none does.
+func (n *node[T]) getLooseIndex(rect Rect[T]) int {
+	subWidth := n.bounds.Width / 2
+	subHeight := n.bounds.Height / 2
+	x := n.bounds.X
+	y := n.bounds.Y
+
+	quadrants := [4]Rect[T]{
+		{x + subWidth, y, subWidth, subHeight},             // top right
+		{x, y, subWidth, subHeight},                        // top left
+		{x, y + subHeight, subWidth, subHeight},            // bottom left
+		{x + subWidth, y + subHeight, subWidth, subHeight}, // bottom right
+	}
+
+	for i, quadrant := range quadrants {
+		if inflateAroundCenter(quadrant, n.looseness).Contain(rect) {
+			return i
+		}
+	}
+	return -1
+}
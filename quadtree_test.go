@@ -0,0 +1,61 @@
+package quadtree
+
+import "testing"
+
+func TestClearResetsTree(t *testing.T) {
+	qt := NewQuadTree(100, 100, 1, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), nil))
+	qt.Insert(NewObject(2, MakeRect(90, 90, 2, 2), nil))
+
+	qt.Clear()
+
+	if _, ok := qt.Get(1); ok {
+		t.Fatal("Get(1) found an object after Clear")
+	}
+	count := 0
+	qt.Foreach(func(obj Object) { count++ })
+	if count != 0 {
+		t.Fatalf("Foreach visited %d objects after Clear, want 0", count)
+	}
+
+	// The tree must still be usable after Clear.
+	qt.Insert(NewObject(3, MakeRect(5, 5, 2, 2), nil))
+	if _, ok := qt.Get(3); !ok {
+		t.Fatal("insert after Clear did not take effect")
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	qt := NewQuadTree(100, 100, 1, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), nil))
+
+	objs := []Object{
+		NewObject(2, MakeRect(20, 20, 2, 2), nil),
+		NewObject(3, MakeRect(30, 30, 2, 2), nil),
+	}
+	qt.Rebuild(objs)
+
+	if _, ok := qt.Get(1); ok {
+		t.Fatal("Rebuild left a stale object from before the call")
+	}
+	for _, obj := range objs {
+		if _, ok := qt.Get(obj.Id); !ok {
+			t.Fatalf("Rebuild dropped object %d", obj.Id)
+		}
+	}
+}
+
+func TestGetAndRemove(t *testing.T) {
+	qt := NewQuadTree(100, 100, 1, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 2, 2), "a"))
+
+	obj, ok := qt.Get(1)
+	if !ok || obj.Data != "a" {
+		t.Fatalf("Get(1) = %v, %v, want {Data: a}, true", obj, ok)
+	}
+
+	qt.Remove(obj.Bounds, 1)
+	if _, ok := qt.Get(1); ok {
+		t.Fatal("object still present after Remove")
+	}
+}
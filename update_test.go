@@ -0,0 +1,67 @@
+package quadtree
+
+import "testing"
+
+// TestUpdateDescendsIntoChild reproduces a bug where Update used
+// node.bounds.Contain(newBounds) to decide whether to mutate in place.
+// Since a non-leaf ancestor's bounds span all of its children, that check
+// was true for almost any move within the subtree, so an object that
+// straddled a split and was later moved to bounds that fit cleanly inside
+// one child's quadrant never descended — it stayed at the ancestor forever.
+func TestUpdateDescendsIntoChild(t *testing.T) {
+	qt := NewQuadTree(100, 100, 1, 4)
+
+	// Object 1 straddles the root's split line, so it stays at the root.
+	qt.Insert(NewObject(1, MakeRect(40, 10, 20, 10), nil))
+	// Object 2 forces the root to split.
+	qt.Insert(NewObject(2, MakeRect(60, 10, 5, 5), nil))
+
+	root := qt.root
+	if len(root.children) == 0 {
+		t.Fatal("root did not split")
+	}
+	if _, ok := root.objects[1]; !ok {
+		t.Fatal("object 1 should straddle the split and stay at the root")
+	}
+
+	// Move object 1 to bounds that fit cleanly inside one child's quadrant.
+	newBounds := MakeRect(55, 5, 5, 5)
+	if idx := root.getIndex(newBounds); idx == -1 {
+		t.Fatalf("test setup invalid: newBounds does not fit a single child (getIndex=%d)", idx)
+	}
+	if !qt.Update(1, newBounds, nil) {
+		t.Fatal("Update reported the object as not found")
+	}
+
+	if _, ok := root.objects[1]; ok {
+		t.Fatal("object 1 still indexed at the root after it should have descended")
+	}
+	node, ok := qt.index[1]
+	if !ok {
+		t.Fatal("object 1 missing from the id index after Update")
+	}
+	if node == root {
+		t.Fatal("object 1 did not descend into a child after Update")
+	}
+	obj, ok := qt.Get(1)
+	if !ok || obj.Bounds != newBounds {
+		t.Fatalf("Get(1) = %v, %v, want updated bounds %v", obj, ok, newBounds)
+	}
+}
+
+func TestUpdateMovesToAncestor(t *testing.T) {
+	qt := NewQuadTree(100, 100, 1, 4)
+	qt.Insert(NewObject(1, MakeRect(10, 10, 5, 5), nil))
+	qt.Insert(NewObject(2, MakeRect(15, 15, 5, 5), nil))
+
+	// Move object 1 entirely outside the bounds covered by its current
+	// node; it must still be found afterwards.
+	newBounds := MakeRect(90, 90, 5, 5)
+	if !qt.Update(1, newBounds, "moved") {
+		t.Fatal("Update reported the object as not found")
+	}
+	obj, ok := qt.Get(1)
+	if !ok || obj.Bounds != newBounds || obj.Data != "moved" {
+		t.Fatalf("Get(1) = %v, %v, want updated bounds/data", obj, ok)
+	}
+}